@@ -0,0 +1,38 @@
+package promlazy
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// NewCounterFunc works like the function of the same name in the prometheus package,
+// but it defers registration like every other constructor in this package.
+//
+// Func metrics have no write-side method to hook into, so "first use" cannot mean the
+// same thing it does for NewCounter. Instead, a Func metric is registered the first time
+// *any* metric from the factory is used, not necessarily on its own account: it simply
+// joins the batch of collectors that Factory.Register imports on the first write anywhere
+// in the factory. If a factory only ever declares Func metrics, call Factory.Register
+// explicitly (e.g. at startup) to make them visible.
+func (f *Factory) NewCounterFunc(opts prometheus.CounterOpts, function func() float64) prometheus.CounterFunc {
+	c := prometheus.NewCounterFunc(f.opts.applyCounterOpts(opts), function)
+	f.addCollector(c)
+	return c
+}
+
+// NewGaugeFunc works like the function of the same name in the prometheus package,
+// but it defers registration like every other constructor in this package.
+//
+// See NewCounterFunc for how "first use" is defined for Func metrics.
+func (f *Factory) NewGaugeFunc(opts prometheus.GaugeOpts, function func() float64) prometheus.GaugeFunc {
+	c := prometheus.NewGaugeFunc(f.opts.applyGaugeOpts(opts), function)
+	f.addCollector(c)
+	return c
+}
+
+// NewUntypedFunc works like the function of the same name in the prometheus package,
+// but it defers registration like every other constructor in this package.
+//
+// See NewCounterFunc for how "first use" is defined for Func metrics.
+func (f *Factory) NewUntypedFunc(opts prometheus.UntypedOpts, function func() float64) prometheus.UntypedFunc {
+	c := prometheus.NewUntypedFunc(f.opts.applyUntypedOpts(opts), function)
+	f.addCollector(c)
+	return c
+}
@@ -0,0 +1,26 @@
+package promlazy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyFunc(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	batch := With(registry)
+	_ = batch.NewGaugeFunc(prometheus.GaugeOpts{Name: "my_func_1"}, func() float64 { return 1 })
+	counter := batch.NewCounter(prometheus.CounterOpts{Name: "my_counter_1"})
+	// Gather before writing to the regular counter.
+	// We expect to gather no metrics, including the Func metric.
+	gather1, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, gather1, 0)
+	// Writing to any metric in the factory registers the whole batch, Func metrics included.
+	counter.Inc()
+	gather2, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, gather2, 2)
+}
@@ -12,10 +12,17 @@ import (
 
 // Factory creates a batch of "lazy" Prometheus metrics that delay registration until first use.
 //
+// A Factory is safe for concurrent use: metrics may be created and written to from multiple
+// goroutines, including from multiple init paths that race to declare the same batch. Once
+// Register has fired, any metric created afterwards is registered into the target Registerer
+// immediately, since there is no longer a batch to defer it into.
+//
 // This package will always panic if registration fails.
 type Factory struct {
 	r          prometheus.Registerer
-	initOnce   sync.Once
+	opts       Options
+	mu         sync.Mutex
+	registered bool
 	collectors []prometheus.Collector
 }
 
@@ -25,7 +32,12 @@ func New() Factory {
 }
 
 // With creates a factory of lazy metrics that eventually register.
-func With(r prometheus.Registerer) Factory { return Factory{r: r} }
+//
+// Metrics created this way get none of the Options defaults: no namespace, subsystem or const
+// labels are applied, and no Go/process collectors are added. Use NewWithOptions for that.
+func With(r prometheus.Registerer) Factory {
+	return NewWithOptions(r, Options{DisableGoCollector: true, DisableProcessCollector: true})
+}
 
 // Register imports all collectors into the registry.
 //
@@ -37,18 +49,58 @@ func With(r prometheus.Registerer) Factory { return Factory{r: r} }
 // For example, if you are trying to define metrics on a network client, it makes sense to Register the factory
 // whenever a client is instantiated.
 func (f *Factory) Register() {
-	f.initOnce.Do(f.init)
-}
-
-func (f *Factory) init() {
-	f.r.MustRegister(f.collectors...)
+	f.mu.Lock()
+	if f.registered {
+		f.mu.Unlock()
+		return
+	}
+	f.registered = true
+	if !f.opts.DisableGoCollector {
+		f.collectors = append(f.collectors, prometheus.NewGoCollector())
+	}
+	if !f.opts.DisableProcessCollector {
+		f.collectors = append(f.collectors, prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	}
+	collectors := f.collectors
+	f.mu.Unlock()
+
+	// init runs unlocked: with Pedantic set it calls Gather, which runs every collector's
+	// Collect (including Func metrics' user callbacks), and must not hold f.mu while doing
+	// so or a callback that re-enters this Factory would deadlock.
+	f.init(collectors)
+}
+
+func (f *Factory) init(collectors []prometheus.Collector) {
+	if f.opts.Pedantic {
+		// Pedantic checks (Desc/Metric consistency) only run during Gather, not Register,
+		// so the batch has to actually be gathered here for issues to panic now rather
+		// than surfacing later at scrape time via f.r.
+		pr := prometheus.NewPedanticRegistry()
+		pr.MustRegister(collectors...)
+		if _, err := pr.Gather(); err != nil {
+			panic(err)
+		}
+	}
+	f.r.MustRegister(collectors...)
+}
+
+// addCollector adds c to the batch, or, if the batch has already been registered, registers
+// c into f.r right away so metrics declared late still work.
+func (f *Factory) addCollector(c prometheus.Collector) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.registered {
+		f.r.MustRegister(c)
+		return
+	}
+	f.collectors = append(f.collectors, c)
 }
 
 // NewCounter works like the function of the same name in the prometheus package,
 // but it automatically registers the Counter on first use.
 func (f *Factory) NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
-	c := prometheus.NewCounter(opts)
-	f.collectors = append(f.collectors, c)
+	c := prometheus.NewCounter(f.opts.applyCounterOpts(opts))
+	f.addCollector(c)
 	return lazyCounter{c, f}
 }
 
@@ -70,8 +122,8 @@ func (l lazyCounter) Add(x float64) {
 // NewGauge works like the function of the same name in the prometheus package,
 // but it automatically registers the Gauge on first use.
 func (f *Factory) NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
-	c := prometheus.NewGauge(opts)
-	f.collectors = append(f.collectors, c)
+	c := prometheus.NewGauge(f.opts.applyGaugeOpts(opts))
+	f.addCollector(c)
 	return lazyGauge{c, f}
 }
 
@@ -113,8 +165,8 @@ func (l lazyGauge) SetToCurrentTime() {
 // NewSummary works like the function of the same name in the prometheus package,
 // but it automatically registers the Summary on first use.
 func (f *Factory) NewSummary(opts prometheus.SummaryOpts) prometheus.Summary {
-	c := prometheus.NewSummary(opts)
-	f.collectors = append(f.collectors, c)
+	c := prometheus.NewSummary(f.opts.applySummaryOpts(opts))
+	f.addCollector(c)
 	return lazySummary{c, f}
 }
 
@@ -131,8 +183,8 @@ func (l lazySummary) Observe(x float64) {
 // NewHistogram works like the function of the same name in the prometheus package,
 // but it automatically registers the Histogram on first use.
 func (f *Factory) NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
-	c := prometheus.NewHistogram(opts)
-	f.collectors = append(f.collectors, c)
+	c := prometheus.NewHistogram(f.opts.applyHistogramOpts(opts))
+	f.addCollector(c)
 	return lazyHistogram{c, f}
 }
 
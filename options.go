@@ -0,0 +1,112 @@
+package promlazy
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Options configures defaults shared by every metric a Factory creates.
+//
+// This mirrors the configuration surface of xmidt-org/themis's xmetrics registry, so that
+// packages adopting promlazy across a codebase don't have to repeat namespace/const-label
+// boilerplate in every metric declaration.
+type Options struct {
+	// DefaultNamespace is used for metrics that don't set their own Namespace.
+	DefaultNamespace string
+
+	// DefaultSubsystem is used for metrics that don't set their own Subsystem.
+	DefaultSubsystem string
+
+	// ConstLabels are merged into every metric's own ConstLabels.
+	// It is a panic for a metric to declare a const label already present here.
+	ConstLabels map[string]string
+
+	// Pedantic makes Factory.Register gather the batch through a prometheus.NewPedanticRegistry
+	// first, so Desc/Metric consistency issues panic at Register time rather than surfacing at
+	// scrape time. Metrics created after Register has fired are registered into the target
+	// Registerer directly, without this check.
+	Pedantic bool
+
+	// DisableGoCollector skips adding prometheus.NewGoCollector to the registered batch.
+	DisableGoCollector bool
+
+	// DisableProcessCollector skips adding prometheus.NewProcessCollector to the registered batch.
+	DisableProcessCollector bool
+}
+
+// NewWithOptions creates a factory of lazy metrics that eventually register, applying the
+// given Options' defaults to every metric the factory creates.
+func NewWithOptions(r prometheus.Registerer, opts Options) Factory {
+	return Factory{r: r, opts: opts}
+}
+
+func (o Options) applyCounterOpts(opts prometheus.CounterOpts) prometheus.CounterOpts {
+	if opts.Namespace == "" {
+		opts.Namespace = o.DefaultNamespace
+	}
+	if opts.Subsystem == "" {
+		opts.Subsystem = o.DefaultSubsystem
+	}
+	opts.ConstLabels = o.mergeConstLabels(opts.ConstLabels)
+	return opts
+}
+
+func (o Options) applyGaugeOpts(opts prometheus.GaugeOpts) prometheus.GaugeOpts {
+	if opts.Namespace == "" {
+		opts.Namespace = o.DefaultNamespace
+	}
+	if opts.Subsystem == "" {
+		opts.Subsystem = o.DefaultSubsystem
+	}
+	opts.ConstLabels = o.mergeConstLabels(opts.ConstLabels)
+	return opts
+}
+
+func (o Options) applyHistogramOpts(opts prometheus.HistogramOpts) prometheus.HistogramOpts {
+	if opts.Namespace == "" {
+		opts.Namespace = o.DefaultNamespace
+	}
+	if opts.Subsystem == "" {
+		opts.Subsystem = o.DefaultSubsystem
+	}
+	opts.ConstLabels = o.mergeConstLabels(opts.ConstLabels)
+	return opts
+}
+
+func (o Options) applySummaryOpts(opts prometheus.SummaryOpts) prometheus.SummaryOpts {
+	if opts.Namespace == "" {
+		opts.Namespace = o.DefaultNamespace
+	}
+	if opts.Subsystem == "" {
+		opts.Subsystem = o.DefaultSubsystem
+	}
+	opts.ConstLabels = o.mergeConstLabels(opts.ConstLabels)
+	return opts
+}
+
+func (o Options) applyUntypedOpts(opts prometheus.UntypedOpts) prometheus.UntypedOpts {
+	if opts.Namespace == "" {
+		opts.Namespace = o.DefaultNamespace
+	}
+	if opts.Subsystem == "" {
+		opts.Subsystem = o.DefaultSubsystem
+	}
+	opts.ConstLabels = o.mergeConstLabels(opts.ConstLabels)
+	return opts
+}
+
+// mergeConstLabels unions the factory's default const labels with a metric's own, panicking
+// if the same label key is set on both sides.
+func (o Options) mergeConstLabels(own prometheus.Labels) prometheus.Labels {
+	if len(o.ConstLabels) == 0 {
+		return own
+	}
+	merged := make(prometheus.Labels, len(o.ConstLabels)+len(own))
+	for k, v := range o.ConstLabels {
+		merged[k] = v
+	}
+	for k, v := range own {
+		if _, ok := merged[k]; ok {
+			panic("promlazy: const label " + k + " is set by both Options.ConstLabels and the metric's own ConstLabels")
+		}
+		merged[k] = v
+	}
+	return merged
+}
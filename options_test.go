@@ -0,0 +1,89 @@
+package promlazy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionsDefaults(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	batch := NewWithOptions(registry, Options{
+		DefaultNamespace:        "ns",
+		DefaultSubsystem:        "sub",
+		ConstLabels:             prometheus.Labels{"env": "test"},
+		DisableGoCollector:      true,
+		DisableProcessCollector: true,
+	})
+	counter := batch.NewCounter(prometheus.CounterOpts{Name: "my_metric"})
+	counter.Inc()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	assert.Equal(t, "ns_sub_my_metric", families[0].GetName())
+	require.Len(t, families[0].Metric, 1)
+	labels := families[0].Metric[0].GetLabel()
+	require.Len(t, labels, 1)
+	assert.Equal(t, "env", labels[0].GetName())
+	assert.Equal(t, "test", labels[0].GetValue())
+}
+
+func TestOptionsConstLabelConflictPanics(t *testing.T) {
+	batch := NewWithOptions(prometheus.NewRegistry(), Options{
+		ConstLabels: prometheus.Labels{"env": "test"},
+	})
+	assert.Panics(t, func() {
+		batch.NewCounter(prometheus.CounterOpts{
+			Name:        "my_metric",
+			ConstLabels: prometheus.Labels{"env": "prod"},
+		})
+	})
+}
+
+func TestOptionsDefaultCollectors(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	batch := NewWithOptions(registry, Options{})
+	counter := batch.NewCounter(prometheus.CounterOpts{Name: "my_metric"})
+	counter.Inc()
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	// The Go and process collectors should have been registered alongside my_metric.
+	assert.Greater(t, len(families), 1)
+}
+
+// inconsistentCollector advertises one Desc via Describe but collects a Metric built from a
+// different Desc of the same name, which only a pedantic Gather rejects.
+type inconsistentCollector struct {
+	desc *prometheus.Desc
+}
+
+func (c *inconsistentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *inconsistentCollector) Collect(ch chan<- prometheus.Metric) {
+	badDesc := prometheus.NewDesc("my_pedantic_metric", "help", nil, prometheus.Labels{"extra": "x"})
+	ch <- prometheus.MustNewConstMetric(badDesc, prometheus.GaugeValue, 1)
+}
+
+func TestOptionsPedanticCatchesDescInconsistency(t *testing.T) {
+	c := &inconsistentCollector{desc: prometheus.NewDesc("my_pedantic_metric", "help", nil, nil)}
+
+	// A non-pedantic registry happily registers and gathers this, since it never checks
+	// a collected Metric's Desc against what Describe advertised.
+	plain := prometheus.NewRegistry()
+	require.NoError(t, plain.Register(c))
+	_, err := plain.Gather()
+	assert.NoError(t, err)
+
+	// The Pedantic option must catch the same inconsistency at Register time.
+	f := NewWithOptions(prometheus.NewRegistry(), Options{Pedantic: true, DisableGoCollector: true, DisableProcessCollector: true})
+	f.addCollector(c)
+	assert.Panics(t, func() {
+		f.Register()
+	})
+}
@@ -0,0 +1,32 @@
+package promlazy
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// TestLazyConcurrent exercises concurrent metric creation and first-use. Run with -race.
+func TestLazyConcurrent(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	batch := With(registry)
+
+	const goroutines = 50
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for i := 0; i < goroutines; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			counter := batch.NewCounter(prometheus.CounterOpts{Name: "my_metric", ConstLabels: prometheus.Labels{"id": strconv.Itoa(i)}})
+			counter.Inc()
+		}()
+	}
+	wg.Wait()
+
+	if _, err := registry.Gather(); err != nil {
+		t.Fatalf("gather failed: %v", err)
+	}
+}
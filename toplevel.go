@@ -0,0 +1,84 @@
+package promlazy
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultFactory is the Factory backing the package-level constructors below. It targets
+// prometheus.DefaultRegisterer, but is itself only constructed the first time one of those
+// constructors is called, so merely importing this package never touches DefaultRegisterer.
+var (
+	defaultFactoryOnce sync.Once
+	defaultFactory     Factory
+)
+
+func defaultFac() *Factory {
+	defaultFactoryOnce.Do(func() {
+		defaultFactory = New()
+	})
+	return &defaultFactory
+}
+
+// Register force-registers every metric declared via the package-level constructors below.
+//
+// This is useful in tests and for fail-fast startup, mirroring Factory.Register.
+func Register() {
+	defaultFac().Register()
+}
+
+// NewCounter works like Factory.NewCounter, targeting prometheus.DefaultRegisterer.
+func NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
+	return defaultFac().NewCounter(opts)
+}
+
+// NewGauge works like Factory.NewGauge, targeting prometheus.DefaultRegisterer.
+func NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	return defaultFac().NewGauge(opts)
+}
+
+// NewSummary works like Factory.NewSummary, targeting prometheus.DefaultRegisterer.
+func NewSummary(opts prometheus.SummaryOpts) prometheus.Summary {
+	return defaultFac().NewSummary(opts)
+}
+
+// NewHistogram works like Factory.NewHistogram, targeting prometheus.DefaultRegisterer.
+func NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
+	return defaultFac().NewHistogram(opts)
+}
+
+// NewCounterVec works like Factory.NewCounterVec, targeting prometheus.DefaultRegisterer.
+func NewCounterVec(opts prometheus.CounterOpts, labelNames []string) CounterVec {
+	return defaultFac().NewCounterVec(opts, labelNames)
+}
+
+// NewGaugeVec works like Factory.NewGaugeVec, targeting prometheus.DefaultRegisterer.
+func NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) GaugeVec {
+	return defaultFac().NewGaugeVec(opts, labelNames)
+}
+
+// NewHistogramVec works like Factory.NewHistogramVec, targeting prometheus.DefaultRegisterer.
+func NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) HistogramVec {
+	return defaultFac().NewHistogramVec(opts, labelNames)
+}
+
+// NewSummaryVec works like Factory.NewSummaryVec, targeting prometheus.DefaultRegisterer.
+func NewSummaryVec(opts prometheus.SummaryOpts, labelNames []string) SummaryVec {
+	return defaultFac().NewSummaryVec(opts, labelNames)
+}
+
+// NewCounterFunc works like Factory.NewCounterFunc, targeting prometheus.DefaultRegisterer.
+func NewCounterFunc(opts prometheus.CounterOpts, function func() float64) prometheus.CounterFunc {
+	return defaultFac().NewCounterFunc(opts, function)
+}
+
+// NewGaugeFunc works like Factory.NewGaugeFunc, targeting prometheus.DefaultRegisterer.
+func NewGaugeFunc(opts prometheus.GaugeOpts, function func() float64) prometheus.GaugeFunc {
+	return defaultFac().NewGaugeFunc(opts, function)
+}
+
+// NewUntypedFunc works like Factory.NewUntypedFunc, targeting prometheus.DefaultRegisterer.
+func NewUntypedFunc(opts prometheus.UntypedOpts, function func() float64) prometheus.UntypedFunc {
+	return defaultFac().NewUntypedFunc(opts, function)
+}
@@ -0,0 +1,29 @@
+package promlazy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopLevel(t *testing.T) {
+	counter := NewCounter(prometheus.CounterOpts{Name: "promlazy_toplevel_test_metric"})
+	gather1, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	for _, mf := range gather1 {
+		assert.NotEqual(t, "promlazy_toplevel_test_metric", mf.GetName())
+	}
+
+	counter.Inc()
+	gather2, err := prometheus.DefaultGatherer.Gather()
+	require.NoError(t, err)
+	var found bool
+	for _, mf := range gather2 {
+		if mf.GetName() == "promlazy_toplevel_test_metric" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
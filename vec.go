@@ -0,0 +1,235 @@
+package promlazy
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// NewCounterVec works like the function of the same name in the prometheus package,
+// but it automatically registers the CounterVec on first use of any of its children.
+func (f *Factory) NewCounterVec(opts prometheus.CounterOpts, labelNames []string) CounterVec {
+	v := prometheus.NewCounterVec(f.opts.applyCounterOpts(opts), labelNames)
+	f.addCollector(v)
+	return CounterVec{v, f}
+}
+
+// CounterVec is a lazy-registering analog of prometheus.CounterVec.
+// Its children stay lazy too: registration only happens when one of them is first used.
+type CounterVec struct {
+	*prometheus.CounterVec
+	*Factory
+}
+
+func (v CounterVec) WithLabelValues(lvs ...string) prometheus.Counter {
+	return lazyCounter{v.CounterVec.WithLabelValues(lvs...), v.Factory}
+}
+
+func (v CounterVec) With(labels prometheus.Labels) prometheus.Counter {
+	return lazyCounter{v.CounterVec.With(labels), v.Factory}
+}
+
+func (v CounterVec) GetMetricWithLabelValues(lvs ...string) (prometheus.Counter, error) {
+	c, err := v.CounterVec.GetMetricWithLabelValues(lvs...)
+	if err != nil {
+		return nil, err
+	}
+	return lazyCounter{c, v.Factory}, nil
+}
+
+func (v CounterVec) GetMetricWith(labels prometheus.Labels) (prometheus.Counter, error) {
+	c, err := v.CounterVec.GetMetricWith(labels)
+	if err != nil {
+		return nil, err
+	}
+	return lazyCounter{c, v.Factory}, nil
+}
+
+func (v CounterVec) CurryWith(labels prometheus.Labels) (CounterVec, error) {
+	curried, err := v.CounterVec.CurryWith(labels)
+	if err != nil {
+		return CounterVec{}, err
+	}
+	return CounterVec{curried, v.Factory}, nil
+}
+
+func (v CounterVec) MustCurryWith(labels prometheus.Labels) CounterVec {
+	curried, err := v.CurryWith(labels)
+	if err != nil {
+		panic(err)
+	}
+	return curried
+}
+
+// NewGaugeVec works like the function of the same name in the prometheus package,
+// but it automatically registers the GaugeVec on first use of any of its children.
+func (f *Factory) NewGaugeVec(opts prometheus.GaugeOpts, labelNames []string) GaugeVec {
+	v := prometheus.NewGaugeVec(f.opts.applyGaugeOpts(opts), labelNames)
+	f.addCollector(v)
+	return GaugeVec{v, f}
+}
+
+// GaugeVec is a lazy-registering analog of prometheus.GaugeVec.
+// Its children stay lazy too: registration only happens when one of them is first used.
+type GaugeVec struct {
+	*prometheus.GaugeVec
+	*Factory
+}
+
+func (v GaugeVec) WithLabelValues(lvs ...string) prometheus.Gauge {
+	return lazyGauge{v.GaugeVec.WithLabelValues(lvs...), v.Factory}
+}
+
+func (v GaugeVec) With(labels prometheus.Labels) prometheus.Gauge {
+	return lazyGauge{v.GaugeVec.With(labels), v.Factory}
+}
+
+func (v GaugeVec) GetMetricWithLabelValues(lvs ...string) (prometheus.Gauge, error) {
+	c, err := v.GaugeVec.GetMetricWithLabelValues(lvs...)
+	if err != nil {
+		return nil, err
+	}
+	return lazyGauge{c, v.Factory}, nil
+}
+
+func (v GaugeVec) GetMetricWith(labels prometheus.Labels) (prometheus.Gauge, error) {
+	c, err := v.GaugeVec.GetMetricWith(labels)
+	if err != nil {
+		return nil, err
+	}
+	return lazyGauge{c, v.Factory}, nil
+}
+
+func (v GaugeVec) CurryWith(labels prometheus.Labels) (GaugeVec, error) {
+	curried, err := v.GaugeVec.CurryWith(labels)
+	if err != nil {
+		return GaugeVec{}, err
+	}
+	return GaugeVec{curried, v.Factory}, nil
+}
+
+func (v GaugeVec) MustCurryWith(labels prometheus.Labels) GaugeVec {
+	curried, err := v.CurryWith(labels)
+	if err != nil {
+		panic(err)
+	}
+	return curried
+}
+
+// NewHistogramVec works like the function of the same name in the prometheus package,
+// but it automatically registers the HistogramVec on first use of any of its children.
+func (f *Factory) NewHistogramVec(opts prometheus.HistogramOpts, labelNames []string) HistogramVec {
+	v := prometheus.NewHistogramVec(f.opts.applyHistogramOpts(opts), labelNames)
+	f.addCollector(v)
+	return HistogramVec{v, f}
+}
+
+// HistogramVec is a lazy-registering analog of prometheus.HistogramVec.
+// Its children stay lazy too: registration only happens when one of them is first used.
+type HistogramVec struct {
+	*prometheus.HistogramVec
+	*Factory
+}
+
+func (v HistogramVec) WithLabelValues(lvs ...string) prometheus.Observer {
+	return lazyObserver{v.HistogramVec.WithLabelValues(lvs...), v.Factory}
+}
+
+func (v HistogramVec) With(labels prometheus.Labels) prometheus.Observer {
+	return lazyObserver{v.HistogramVec.With(labels), v.Factory}
+}
+
+func (v HistogramVec) GetMetricWithLabelValues(lvs ...string) (prometheus.Observer, error) {
+	o, err := v.HistogramVec.GetMetricWithLabelValues(lvs...)
+	if err != nil {
+		return nil, err
+	}
+	return lazyObserver{o, v.Factory}, nil
+}
+
+func (v HistogramVec) GetMetricWith(labels prometheus.Labels) (prometheus.Observer, error) {
+	o, err := v.HistogramVec.GetMetricWith(labels)
+	if err != nil {
+		return nil, err
+	}
+	return lazyObserver{o, v.Factory}, nil
+}
+
+func (v HistogramVec) CurryWith(labels prometheus.Labels) (HistogramVec, error) {
+	curried, err := v.HistogramVec.CurryWith(labels)
+	if err != nil {
+		return HistogramVec{}, err
+	}
+	return HistogramVec{curried.(*prometheus.HistogramVec), v.Factory}, nil
+}
+
+func (v HistogramVec) MustCurryWith(labels prometheus.Labels) HistogramVec {
+	curried, err := v.CurryWith(labels)
+	if err != nil {
+		panic(err)
+	}
+	return curried
+}
+
+// NewSummaryVec works like the function of the same name in the prometheus package,
+// but it automatically registers the SummaryVec on first use of any of its children.
+func (f *Factory) NewSummaryVec(opts prometheus.SummaryOpts, labelNames []string) SummaryVec {
+	v := prometheus.NewSummaryVec(f.opts.applySummaryOpts(opts), labelNames)
+	f.addCollector(v)
+	return SummaryVec{v, f}
+}
+
+// SummaryVec is a lazy-registering analog of prometheus.SummaryVec.
+// Its children stay lazy too: registration only happens when one of them is first used.
+type SummaryVec struct {
+	*prometheus.SummaryVec
+	*Factory
+}
+
+func (v SummaryVec) WithLabelValues(lvs ...string) prometheus.Observer {
+	return lazyObserver{v.SummaryVec.WithLabelValues(lvs...), v.Factory}
+}
+
+func (v SummaryVec) With(labels prometheus.Labels) prometheus.Observer {
+	return lazyObserver{v.SummaryVec.With(labels), v.Factory}
+}
+
+func (v SummaryVec) GetMetricWithLabelValues(lvs ...string) (prometheus.Observer, error) {
+	o, err := v.SummaryVec.GetMetricWithLabelValues(lvs...)
+	if err != nil {
+		return nil, err
+	}
+	return lazyObserver{o, v.Factory}, nil
+}
+
+func (v SummaryVec) GetMetricWith(labels prometheus.Labels) (prometheus.Observer, error) {
+	o, err := v.SummaryVec.GetMetricWith(labels)
+	if err != nil {
+		return nil, err
+	}
+	return lazyObserver{o, v.Factory}, nil
+}
+
+func (v SummaryVec) CurryWith(labels prometheus.Labels) (SummaryVec, error) {
+	curried, err := v.SummaryVec.CurryWith(labels)
+	if err != nil {
+		return SummaryVec{}, err
+	}
+	return SummaryVec{curried.(*prometheus.SummaryVec), v.Factory}, nil
+}
+
+func (v SummaryVec) MustCurryWith(labels prometheus.Labels) SummaryVec {
+	curried, err := v.CurryWith(labels)
+	if err != nil {
+		panic(err)
+	}
+	return curried
+}
+
+// lazyObserver registers its factory on the first observation, used for the
+// Observer values returned by HistogramVec and SummaryVec children.
+type lazyObserver struct {
+	prometheus.Observer
+	*Factory
+}
+
+func (l lazyObserver) Observe(x float64) {
+	l.Factory.Register()
+	l.Observer.Observe(x)
+}
@@ -0,0 +1,55 @@
+package promlazy
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyVec(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	batch := With(registry)
+	counterVec := batch.NewCounterVec(prometheus.CounterOpts{Name: "my_vec_1"}, []string{"label"})
+	gaugeVec := batch.NewGaugeVec(prometheus.GaugeOpts{Name: "my_vec_2"}, []string{"label"})
+	histogramVec := batch.NewHistogramVec(prometheus.HistogramOpts{Name: "my_vec_3"}, []string{"label"})
+	summaryVec := batch.NewSummaryVec(prometheus.SummaryOpts{Name: "my_vec_4"}, []string{"label"})
+	// Gather before touching any child.
+	// We expect to gather no metrics.
+	gather1, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, gather1, 0)
+	// Write a value to one child of each vec.
+	counterVec.WithLabelValues("a").Inc()
+	gaugeVec.With(prometheus.Labels{"label": "a"}).Set(1)
+	histogramVec.WithLabelValues("a").Observe(1)
+	summaryVec.WithLabelValues("a").Observe(1)
+	// Gather metrics again. The previous writes should have registered the vecs.
+	gather2, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, gather2, 4)
+}
+
+func TestLazyVecCurryWith(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	batch := With(registry)
+	histogramVec := batch.NewHistogramVec(prometheus.HistogramOpts{Name: "my_curried_vec_1"}, []string{"a", "b"})
+	summaryVec := batch.NewSummaryVec(prometheus.SummaryOpts{Name: "my_curried_vec_2"}, []string{"a", "b"})
+
+	curriedHistogram, err := histogramVec.CurryWith(prometheus.Labels{"a": "x"})
+	require.NoError(t, err)
+	curriedSummary := summaryVec.MustCurryWith(prometheus.Labels{"a": "x"})
+
+	// Gather before touching any curried child.
+	gather1, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, gather1, 0)
+
+	curriedHistogram.WithLabelValues("y").Observe(1)
+	curriedSummary.WithLabelValues("y").Observe(1)
+
+	gather2, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, gather2, 2)
+}